@@ -0,0 +1,199 @@
+// Package ast declares the types used to represent a parsed Prisma
+// schema, mirroring the role go/ast plays for Go source: a typed tree
+// that downstream tools (filtering, formatting, diagnostics) walk
+// instead of re-deriving structure from raw text.
+package ast
+
+import "github.com/steebchen/prisma-client-go/generator/schema/token"
+
+// Node is implemented by every AST node and reports where it starts in
+// the source.
+type Node interface {
+	Pos() token.Position
+	End() token.Position
+}
+
+// Comment is a single "//" or "///" comment line.
+type Comment struct {
+	Slash token.Position
+	Text  string // comment text, including the leading slashes
+	IsDoc bool   // true for "///" triple-slash doc comments
+}
+
+func (c *Comment) Pos() token.Position { return c.Slash }
+func (c *Comment) End() token.Position { return c.Slash }
+
+// CommentGroup is a sequence of comment lines with no blank line between
+// them, attached to the declaration that immediately follows, the same
+// way go/ast.CommentGroup attaches doc comments.
+type CommentGroup struct {
+	List []*Comment
+}
+
+func (g *CommentGroup) Pos() token.Position { return g.List[0].Pos() }
+func (g *CommentGroup) End() token.Position { return g.List[len(g.List)-1].Pos() }
+
+// Text returns the concatenated, comment-marker-stripped text of the
+// group.
+func (g *CommentGroup) Text() string {
+	var out string
+	for i, c := range g.List {
+		text := c.Text
+		if c.IsDoc {
+			text = text[3:]
+		} else {
+			text = text[2:]
+		}
+		if i > 0 {
+			out += "\n"
+		}
+		out += text
+	}
+	return out
+}
+
+// AttributeExpr is a field attribute ("@id") or block attribute
+// ("@@unique(...)") together with its arguments.
+type AttributeExpr struct {
+	AtPos token.Position
+	Name  string // attribute name without the leading @ or @@
+	Block bool   // true for @@ block-level attributes
+	Args  []string
+	// HasParens records whether the attribute was written with a "(...)"
+	// argument list at all, so "@default()" (HasParens true, Args nil)
+	// can be told apart from "@id" (HasParens false) and re-emitted with
+	// its parentheses instead of losing them.
+	HasParens bool
+}
+
+func (a *AttributeExpr) Pos() token.Position { return a.AtPos }
+func (a *AttributeExpr) End() token.Position { return a.AtPos }
+
+// FieldDecl is a single field inside a model block, e.g.:
+//
+//	email String @unique
+type FieldDecl struct {
+	Doc        *CommentGroup
+	NamePos    token.Position
+	Name       string
+	Type       string
+	Optional   bool
+	List       bool
+	Attributes []*AttributeExpr
+	LineEnd    token.Position
+	// Comment is a "//" or "///" comment trailing the field on the same
+	// line (e.g. "id Int @id // primary key"), kept separately from Doc
+	// so it can be re-emitted after the field's attributes instead of
+	// being discarded.
+	Comment *Comment
+}
+
+func (f *FieldDecl) Pos() token.Position { return f.NamePos }
+func (f *FieldDecl) End() token.Position { return f.LineEnd }
+
+// KeyValueDecl is a `name = value` assignment inside a generator or
+// datasource block.
+type KeyValueDecl struct {
+	Doc     *CommentGroup
+	NamePos token.Position
+	Name    string
+	Value   string
+	LineEnd token.Position
+}
+
+func (k *KeyValueDecl) Pos() token.Position { return k.NamePos }
+func (k *KeyValueDecl) End() token.Position { return k.LineEnd }
+
+// EnumValueDecl is a single value inside an enum block.
+type EnumValueDecl struct {
+	Doc        *CommentGroup
+	NamePos    token.Position
+	Name       string
+	Attributes []*AttributeExpr
+	LineEnd    token.Position
+}
+
+func (e *EnumValueDecl) Pos() token.Position { return e.NamePos }
+func (e *EnumValueDecl) End() token.Position { return e.LineEnd }
+
+// Decl is implemented by every top-level block declaration.
+type Decl interface {
+	Node
+	declNode()
+	Kind() string
+	Ident() string
+}
+
+type declBase struct {
+	Doc        *CommentGroup
+	KeywordPos token.Position
+	Name       string
+	Lbrace     token.Position
+	Rbrace     token.Position
+}
+
+func (d *declBase) declNode()           {}
+func (d *declBase) Pos() token.Position { return d.KeywordPos }
+func (d *declBase) End() token.Position { return d.Rbrace }
+func (d *declBase) Ident() string       { return d.Name }
+
+// GeneratorDecl is a `generator <name> { ... }` block.
+type GeneratorDecl struct {
+	declBase
+	Assignments []*KeyValueDecl
+}
+
+func (d *GeneratorDecl) Kind() string { return "generator" }
+
+// DatasourceDecl is a `datasource <name> { ... }` block.
+type DatasourceDecl struct {
+	declBase
+	Assignments []*KeyValueDecl
+}
+
+func (d *DatasourceDecl) Kind() string { return "datasource" }
+
+// ModelDecl is a `model <name> { ... }` block.
+type ModelDecl struct {
+	declBase
+	Fields     []*FieldDecl
+	Attributes []*AttributeExpr // block-level @@ attributes
+}
+
+func (d *ModelDecl) Kind() string { return "model" }
+
+// EnumDecl is an `enum <name> { ... }` block.
+type EnumDecl struct {
+	declBase
+	Values []*EnumValueDecl
+}
+
+func (d *EnumDecl) Kind() string { return "enum" }
+
+// OtherDecl captures a block or statement the parser does not assign
+// special meaning to, so unrecognized content round-trips unchanged
+// instead of being rejected.
+type OtherDecl struct {
+	declBase
+	Raw string
+	// IsBlock is true when Raw is a whole "<keyword> <name> { ... }"
+	// block with an unrecognized keyword (e.g. a newer Prisma block
+	// kind such as "view"), as opposed to a single free-floating line
+	// (a stray comment). The formatter separates IsBlock decls from
+	// their neighbors with a blank line the same way it does for known
+	// blocks; free-floating lines are kept glued to what precedes them
+	// and separated from what follows.
+	IsBlock bool
+}
+
+func (d *OtherDecl) Kind() string { return "other" }
+
+// File is the root node produced by parsing a single schema file.
+type File struct {
+	Filename string
+	Decls    []Decl
+	// Comments is left nil by the parser: it records comments only in
+	// the Doc and Comment fields of the nodes they precede or trail, and
+	// keeps free-floating top-level ones as OtherDecl.Raw.
+	Comments []*CommentGroup
+}
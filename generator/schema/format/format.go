@@ -0,0 +1,197 @@
+// Package format implements canonical formatting of Prisma schema ASTs,
+// the way go/format does for Go source: it renders a parsed *ast.File
+// back into text with normalized indentation, column-aligned fields, and
+// collapsed blank lines, so equivalent schemas always print identically
+// regardless of how the author originally spaced them.
+package format
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/steebchen/prisma-client-go/generator/schema/ast"
+	"github.com/steebchen/prisma-client-go/generator/schema/parser"
+)
+
+const indent = "  " // two spaces, per Prisma schema convention
+
+// Format parses src and returns its canonical formatting.
+func Format(src []byte) ([]byte, error) {
+	file, err := parser.Parse("", src)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse schema: %w", err)
+	}
+	return FormatFile(file), nil
+}
+
+// FormatFile renders an already-parsed schema file to canonical text.
+// Callers that filter or merge an *ast.File's Decls before formatting
+// (e.g. FilterByGenerator) can call this directly to skip re-parsing.
+func FormatFile(file *ast.File) []byte {
+	type block struct {
+		decl ast.Decl
+		text string
+	}
+
+	var blocks []block
+	for _, decl := range file.Decls {
+		text := renderDecl(decl)
+		if strings.TrimSpace(text) == "" {
+			continue
+		}
+		blocks = append(blocks, block{decl, text})
+	}
+
+	var out []string
+	for i, b := range blocks {
+		out = append(out, b.text)
+		if i == len(blocks)-1 {
+			continue
+		}
+		// Don't add a blank line separator before a stray comment or
+		// other passthrough line, but do separate a whole unrecognized
+		// block (e.g. a "view" block) the same way known blocks are
+		// separated.
+		if other, isOther := blocks[i+1].decl.(*ast.OtherDecl); isOther && !other.IsBlock {
+			continue
+		}
+		out = append(out, "")
+	}
+
+	return []byte(strings.Join(out, "\n"))
+}
+
+func renderDecl(decl ast.Decl) string {
+	switch d := decl.(type) {
+	case *ast.GeneratorDecl:
+		return renderKeyValueBlock("generator", d.Doc, d.Name, d.Assignments)
+	case *ast.DatasourceDecl:
+		return renderKeyValueBlock("datasource", d.Doc, d.Name, d.Assignments)
+	case *ast.ModelDecl:
+		return renderModel(d)
+	case *ast.EnumDecl:
+		return renderEnum(d)
+	case *ast.OtherDecl:
+		var b strings.Builder
+		writeDoc(&b, d.Doc, "")
+		b.WriteString(d.Raw)
+		return b.String()
+	default:
+		return ""
+	}
+}
+
+func renderKeyValueBlock(keyword string, doc *ast.CommentGroup, name string, assignments []*ast.KeyValueDecl) string {
+	var b strings.Builder
+	writeDoc(&b, doc, "")
+	fmt.Fprintf(&b, "%s %s {\n", keyword, name)
+
+	width := 0
+	for _, kv := range assignments {
+		if len(kv.Name) > width {
+			width = len(kv.Name)
+		}
+	}
+	for _, kv := range assignments {
+		writeDoc(&b, kv.Doc, indent)
+		fmt.Fprintf(&b, "%s%-*s = %s\n", indent, width, kv.Name, kv.Value)
+	}
+
+	b.WriteString("}")
+	return b.String()
+}
+
+func renderModel(d *ast.ModelDecl) string {
+	var b strings.Builder
+	writeDoc(&b, d.Doc, "")
+	fmt.Fprintf(&b, "model %s {\n", d.Name)
+
+	nameWidth, typeWidth := 0, 0
+	for _, f := range d.Fields {
+		if len(f.Name) > nameWidth {
+			nameWidth = len(f.Name)
+		}
+		if len(fieldType(f)) > typeWidth {
+			typeWidth = len(fieldType(f))
+		}
+	}
+	for _, f := range d.Fields {
+		writeDoc(&b, f.Doc, indent)
+		line := fmt.Sprintf("%s%-*s %-*s", indent, nameWidth, f.Name, typeWidth, fieldType(f))
+		if attrs := renderAttributes(f.Attributes); attrs != "" {
+			line += " " + attrs
+		}
+		line = strings.TrimRight(line, " ")
+		if f.Comment != nil {
+			line += " " + f.Comment.Text
+		}
+		b.WriteString(line + "\n")
+	}
+	for _, a := range d.Attributes {
+		fmt.Fprintf(&b, "%s%s\n", indent, renderAttribute(a))
+	}
+
+	b.WriteString("}")
+	return b.String()
+}
+
+func renderEnum(d *ast.EnumDecl) string {
+	var b strings.Builder
+	writeDoc(&b, d.Doc, "")
+	fmt.Fprintf(&b, "enum %s {\n", d.Name)
+
+	for _, v := range d.Values {
+		writeDoc(&b, v.Doc, indent)
+		line := indent + v.Name
+		if attrs := renderAttributes(v.Attributes); attrs != "" {
+			line += " " + attrs
+		}
+		b.WriteString(line + "\n")
+	}
+
+	b.WriteString("}")
+	return b.String()
+}
+
+func fieldType(f *ast.FieldDecl) string {
+	t := f.Type
+	if f.List {
+		t += "[]"
+	}
+	if f.Optional {
+		t += "?"
+	}
+	return t
+}
+
+func renderAttributes(attrs []*ast.AttributeExpr) string {
+	parts := make([]string, len(attrs))
+	for i, a := range attrs {
+		parts[i] = renderAttribute(a)
+	}
+	return strings.Join(parts, " ")
+}
+
+func renderAttribute(a *ast.AttributeExpr) string {
+	marker := "@"
+	if a.Block {
+		marker = "@@"
+	}
+	s := marker + a.Name
+	if a.HasParens {
+		s += "(" + strings.Join(a.Args, ", ") + ")"
+	}
+	return s
+}
+
+// writeDoc writes a doc comment group, one line per entry with its
+// original "//" or "///" marker, indented to match the declaration it's
+// attached to.
+func writeDoc(b *strings.Builder, doc *ast.CommentGroup, indentPrefix string) {
+	if doc == nil {
+		return
+	}
+	for _, c := range doc.List {
+		fmt.Fprintf(b, "%s%s\n", indentPrefix, c.Text)
+	}
+}
@@ -0,0 +1,166 @@
+package format
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFormatRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want string
+	}{
+		{
+			name: "aligns assignment values",
+			src: `generator client {
+  provider = "prisma-client-go"
+  output = "./generated"
+}
+`,
+			want: `generator client {
+  provider = "prisma-client-go"
+  output   = "./generated"
+}`,
+		},
+		{
+			name: "aligns field columns and keeps attributes",
+			src: `model User {
+  id Int @id @default(autoincrement())
+  name String
+}
+`,
+			want: `model User {
+  id   Int    @id @default(autoincrement())
+  name String
+}`,
+		},
+		{
+			name: "preserves braces inside string attribute args",
+			src: `model User {
+  bio String @default("{not a block}")
+}
+`,
+			want: `model User {
+  bio String @default("{not a block}")
+}`,
+		},
+		{
+			name: "keeps a trailing field comment",
+			src: `model User {
+  id Int @id // primary key
+}
+`,
+			want: `model User {
+  id Int @id // primary key
+}`,
+		},
+		{
+			name: "keeps a native-type attribute and negative default",
+			src: `model User {
+  rank Int @default(-1)
+  name String @db.VarChar(255)
+}
+`,
+			want: `model User {
+  rank Int    @default(-1)
+  name String @db.VarChar(255)
+}`,
+		},
+		{
+			name: "keeps empty parens on an attribute",
+			src: `model User {
+  empty String @default()
+}
+`,
+			want: `model User {
+  empty String @default()
+}`,
+		},
+		{
+			name: "separates blank line between blocks",
+			src: `generator client {
+  provider = "prisma-client-go"
+}
+model User {
+  id Int @id
+}
+`,
+			want: `generator client {
+  provider = "prisma-client-go"
+}
+
+model User {
+  id Int @id
+}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Format([]byte(tt.src))
+			if err != nil {
+				t.Fatalf("Format() error = %v", err)
+			}
+			if strings.TrimRight(string(got), "\n") != tt.want {
+				t.Errorf("Format() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatPreservesUnknownBlockVerbatim(t *testing.T) {
+	src := `generator client {
+  provider = "prisma-client-go"
+}
+view UserInfo {
+  id   String
+  name String
+}
+`
+	got, err := Format([]byte(src))
+	if err != nil {
+		t.Fatalf("Format() error = %v", err)
+	}
+	want := `generator client {
+  provider = "prisma-client-go"
+}
+
+view UserInfo {
+  id   String
+  name String
+}`
+	if strings.TrimRight(string(got), "\n") != want {
+		t.Errorf("Format() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestFormatIsIdempotent(t *testing.T) {
+	src := `generator client {
+  provider="prisma-client-go"
+}
+datasource db {
+  provider = "postgresql"
+  url=env("DATABASE_URL")
+}
+model User {
+  id Int @id @default(autoincrement())
+  email String @unique
+  posts Post[]
+}
+enum Role {
+  USER
+  ADMIN
+}
+`
+	once, err := Format([]byte(src))
+	if err != nil {
+		t.Fatalf("Format() error = %v", err)
+	}
+	twice, err := Format(once)
+	if err != nil {
+		t.Fatalf("Format(Format(src)) error = %v", err)
+	}
+	if string(once) != string(twice) {
+		t.Errorf("Format is not idempotent:\nonce:\n%s\ntwice:\n%s", once, twice)
+	}
+}
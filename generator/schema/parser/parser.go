@@ -0,0 +1,545 @@
+// Package parser turns Prisma schema source into an *ast.File, the way
+// go/parser turns Go source into an *ast.File: it consumes tokens from
+// the scanner package rather than scanning raw text, so braces inside
+// string literals or attribute arguments can never be mistaken for block
+// delimiters.
+package parser
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/steebchen/prisma-client-go/generator/schema/ast"
+	"github.com/steebchen/prisma-client-go/generator/schema/scanner"
+	"github.com/steebchen/prisma-client-go/generator/schema/token"
+)
+
+// blockKeywords are the identifiers that open a top-level declaration.
+var blockKeywords = map[string]bool{
+	"generator":  true,
+	"datasource": true,
+	"model":      true,
+	"enum":       true,
+}
+
+// IsBlockKeyword reports whether name is a keyword the parser knows how
+// to open a block with. Exposed so callers that want to flag unknown
+// block kinds (e.g. generator.Diagnose) don't have to duplicate the set.
+func IsBlockKeyword(name string) bool {
+	return blockKeywords[name]
+}
+
+type item struct {
+	pos token.Position
+	tok token.Token
+	lit string
+}
+
+// Error is a single problem found while parsing, carrying enough
+// position information for callers (e.g. generator.Diagnose) to turn it
+// into a structured diagnostic instead of a first-error string.
+type Error struct {
+	Pos token.Position
+	Msg string
+}
+
+func (e Error) String() string { return fmt.Sprintf("%s: %s", e.Pos, e.Msg) }
+
+// Parser consumes the full token stream of a schema file and assembles
+// it into an *ast.File.
+type Parser struct {
+	filename string
+	src      []byte
+	items    []item
+	pos      int // index of the next unconsumed item
+	errors   []Error
+}
+
+// Parse parses a single schema file's content into an *ast.File,
+// reporting only the first problem found. Use ParseWithErrors to collect
+// every problem instead of stopping at the first one.
+func Parse(filename string, content []byte) (*ast.File, error) {
+	file, errs := ParseWithErrors(filename, content)
+	if len(errs) > 0 {
+		return file, fmt.Errorf("failed to parse schema: %s", errs[0])
+	}
+	return file, nil
+}
+
+// ParseWithErrors parses filename's content into an *ast.File and
+// returns every problem found (unmatched braces, illegal characters,
+// ...) instead of stopping at the first one, for tooling that wants to
+// report all of them at once.
+func ParseWithErrors(filename string, content []byte) (*ast.File, []Error) {
+	p := &Parser{filename: filename, src: content}
+	p.scanAll()
+
+	file := &ast.File{Filename: filename}
+	for !p.atEOF() {
+		if decl := p.parseTopLevel(); decl != nil {
+			file.Decls = append(file.Decls, decl)
+		}
+	}
+
+	return file, p.errors
+}
+
+// ParseString is a convenience wrapper around Parse for callers that
+// have the schema as a string rather than a []byte.
+func ParseString(filename, content string) (*ast.File, error) {
+	return Parse(filename, []byte(content))
+}
+
+func (p *Parser) scanAll() {
+	var sc scanner.Scanner
+	sc.Init(p.filename, p.src, func(pos token.Position, msg string) {
+		p.errors = append(p.errors, Error{pos, msg})
+	})
+	for {
+		pos, tok, lit := sc.Scan()
+		p.items = append(p.items, item{pos, tok, lit})
+		if tok == token.EOF {
+			break
+		}
+	}
+}
+
+// recordError appends a parser-level (non-lexical) problem, such as
+// unmatched braces, to the error list returned by ParseWithErrors.
+func (p *Parser) recordError(pos token.Position, msg string) {
+	p.errors = append(p.errors, Error{pos, msg})
+}
+
+func (p *Parser) atEOF() bool {
+	return p.cur().tok == token.EOF
+}
+
+func (p *Parser) cur() item {
+	return p.items[p.pos]
+}
+
+func (p *Parser) advance() item {
+	it := p.items[p.pos]
+	if it.tok != token.EOF {
+		p.pos++
+	}
+	return it
+}
+
+// parseTopLevel consumes either a recognized block declaration, a
+// doc-comment group attached to one, or a single free-floating line
+// (comment or stray content) preserved verbatim as an ast.OtherDecl.
+func (p *Parser) parseTopLevel() ast.Decl {
+	doc, _ := p.collectDocComments()
+
+	cur := p.cur()
+	if cur.tok == token.IDENT && p.peekBlockOpensAt(p.pos) {
+		if doc == nil || doc.End().Line+1 == cur.pos.Line {
+			if blockKeywords[cur.lit] {
+				return p.parseBlock(doc)
+			}
+			// "<ident> <ident> {" with a keyword we don't recognize,
+			// e.g. a newer block kind such as "view": preserve it
+			// verbatim as a single block instead of parsing it (or
+			// splitting it line by line).
+			return p.parseOtherBlock(doc)
+		}
+		// A blank line separates the doc comment from the block it
+		// precedes, so it isn't actually attached; emit it on its own
+		// and parse the block without a doc comment.
+		return p.otherFromComments(doc)
+	}
+
+	// Not a recognized block: the doc comments we collected (if any)
+	// weren't actually attached to anything, so surface them as
+	// ordinary comment lines instead of silently dropping them.
+	if doc != nil {
+		return p.otherFromComments(doc)
+	}
+
+	return p.parseOtherLine()
+}
+
+// peekBlockOpensAt reports whether the tokens starting at idx form
+// "<keyword> <name> {".
+func (p *Parser) peekBlockOpensAt(idx int) bool {
+	if p.items[idx].tok != token.IDENT {
+		return false
+	}
+	if idx+1 >= len(p.items) || p.items[idx+1].tok != token.IDENT {
+		return false
+	}
+	if idx+2 >= len(p.items) || p.items[idx+2].tok != token.LBRACE {
+		return false
+	}
+	return true
+}
+
+// collectDocComments consumes a contiguous run of leading "///" and "//"
+// comments (no blank line between them) and returns them as a
+// CommentGroup attached to whatever follows, the same way go/ast
+// attaches a lead comment to the next declaration. ok reports whether
+// any were consumed.
+func (p *Parser) collectDocComments() (group *ast.CommentGroup, ok bool) {
+	var comments []*ast.Comment
+	lastLine := -1
+	for p.cur().tok == token.DOC_COMMENT || p.cur().tok == token.COMMENT {
+		it := p.cur()
+		if lastLine != -1 && it.pos.Line != lastLine+1 {
+			break
+		}
+		comments = append(comments, &ast.Comment{Slash: it.pos, Text: it.lit, IsDoc: it.tok == token.DOC_COMMENT})
+		lastLine = it.pos.Line
+		p.advance()
+	}
+	if len(comments) == 0 {
+		return nil, false
+	}
+	return &ast.CommentGroup{List: comments}, true
+}
+
+func (p *Parser) otherFromComments(doc *ast.CommentGroup) ast.Decl {
+	lines := make([]string, len(doc.List))
+	for i, c := range doc.List {
+		lines[i] = c.Text
+	}
+	d := &ast.OtherDecl{Raw: strings.Join(lines, "\n")}
+	d.KeywordPos = doc.Pos()
+	d.Rbrace = doc.End()
+	return d
+}
+
+// parseOtherLine consumes every token on the current line (a plain
+// comment, or any other content we don't otherwise recognize at the top
+// level) and wraps it in a single ast.OtherDecl so the line round-trips
+// unchanged instead of being emitted once per token.
+func (p *Parser) parseOtherLine() ast.Decl {
+	start := p.pos
+	first := p.advance()
+	last := first
+	for p.cur().tok != token.EOF && p.cur().pos.Line == first.pos.Line {
+		last = p.advance()
+	}
+	d := &ast.OtherDecl{Raw: p.sliceTokens(p.items[start:p.pos])}
+	d.KeywordPos = first.pos
+	d.Rbrace = last.pos
+	return d
+}
+
+// parseOtherBlock consumes an entire "<keyword> <name> { ... }" block
+// whose keyword isn't one of blockKeywords and preserves it verbatim
+// (braces, indentation, and all) as a single OtherDecl, so it round-trips
+// unchanged through FilterByGenerator/FormatSchema instead of being
+// split line by line and de-indented.
+func (p *Parser) parseOtherBlock(doc *ast.CommentGroup) ast.Decl {
+	start := p.pos
+	p.advance() // keyword
+	p.advance() // name
+	p.advance() // LBRACE
+	depth := 1
+	for depth > 0 && !p.atEOF() {
+		switch p.advance().tok {
+		case token.LBRACE:
+			depth++
+		case token.RBRACE:
+			depth--
+		}
+	}
+	d := &ast.OtherDecl{Raw: p.sliceTokens(p.items[start:p.pos]), IsBlock: true}
+	d.Doc = doc
+	d.KeywordPos = p.items[start].pos
+	d.Rbrace = p.items[p.pos-1].pos
+	return d
+}
+
+// parseBlock parses a "<keyword> <name> { ... }" declaration. The
+// opening keyword and name tokens are known to exist at the current
+// position (peekBlockOpensAt was already checked by the caller).
+func (p *Parser) parseBlock(doc *ast.CommentGroup) ast.Decl {
+	keyword := p.advance()
+	name := p.advance()
+	lbrace := p.advance() // LBRACE
+
+	switch keyword.lit {
+	case "datasource":
+		assignments := p.parseAssignments()
+		rbrace := p.expectRbrace(keyword)
+		d := &ast.DatasourceDecl{Assignments: assignments}
+		d.Doc, d.KeywordPos, d.Name, d.Lbrace, d.Rbrace = doc, keyword.pos, name.lit, lbrace.pos, rbrace
+		return d
+	case "generator":
+		assignments := p.parseAssignments()
+		rbrace := p.expectRbrace(keyword)
+		d := &ast.GeneratorDecl{Assignments: assignments}
+		d.Doc, d.KeywordPos, d.Name, d.Lbrace, d.Rbrace = doc, keyword.pos, name.lit, lbrace.pos, rbrace
+		return d
+	case "model":
+		fields, attrs := p.parseModelBody()
+		rbrace := p.expectRbrace(keyword)
+		d := &ast.ModelDecl{Fields: fields, Attributes: attrs}
+		d.Doc, d.KeywordPos, d.Name, d.Lbrace, d.Rbrace = doc, keyword.pos, name.lit, lbrace.pos, rbrace
+		return d
+	default: // "enum"
+		values := p.parseEnumBody()
+		rbrace := p.expectRbrace(keyword)
+		d := &ast.EnumDecl{Values: values}
+		d.Doc, d.KeywordPos, d.Name, d.Lbrace, d.Rbrace = doc, keyword.pos, name.lit, lbrace.pos, rbrace
+		return d
+	}
+}
+
+// expectRbrace consumes the token expected to be the closing brace of
+// the block started by keyword. If the input ran out first, it records
+// an unmatched-braces error instead of silently treating EOF as the
+// closing brace.
+func (p *Parser) expectRbrace(keyword item) token.Position {
+	rbrace := p.advance()
+	if rbrace.tok != token.RBRACE {
+		p.recordError(keyword.pos, fmt.Sprintf("unmatched '{' for %s block", keyword.lit))
+		return rbrace.pos
+	}
+	return rbrace.pos
+}
+
+// parseStatementTokens consumes the tokens making up a single statement:
+// everything starting at the current position up to (but not including)
+// a token on a later line, unless parentheses are still open, which
+// allows attribute arguments to span multiple lines. A "//" or "///"
+// comment trailing the statement on its own line is split off and
+// returned separately rather than left mixed into stmt, so callers don't
+// have to special-case a comment token turning up among value/attribute
+// tokens (and, worse, being sliced into the statement's raw text).
+func (p *Parser) parseStatementTokens() (stmt []item, trailingComment *item) {
+	if p.cur().tok == token.RBRACE {
+		return nil, nil
+	}
+	startLine := p.cur().pos.Line
+	depth := 0
+	for {
+		cur := p.cur()
+		if cur.tok == token.EOF {
+			break
+		}
+		if depth == 0 && len(stmt) > 0 && cur.pos.Line != startLine {
+			break
+		}
+		if depth == 0 && cur.tok == token.RBRACE {
+			break
+		}
+		if cur.tok == token.LPAREN {
+			depth++
+		} else if cur.tok == token.RPAREN {
+			depth--
+		}
+		stmt = append(stmt, p.advance())
+	}
+	if n := len(stmt); n > 0 && (stmt[n-1].tok == token.COMMENT || stmt[n-1].tok == token.DOC_COMMENT) {
+		trailingComment = &stmt[n-1]
+		stmt = stmt[:n-1]
+	}
+	return stmt, trailingComment
+}
+
+// parseAssignments reads `name = value` statements until the closing
+// brace of a generator or datasource block.
+func (p *Parser) parseAssignments() []*ast.KeyValueDecl {
+	var out []*ast.KeyValueDecl
+	for p.cur().tok != token.RBRACE && !p.atEOF() {
+		doc, _ := p.collectDocComments()
+		if p.cur().tok == token.RBRACE || p.atEOF() {
+			break
+		}
+		stmt, _ := p.parseStatementTokens()
+		if len(stmt) == 0 {
+			continue
+		}
+		kv := &ast.KeyValueDecl{Doc: doc, NamePos: stmt[0].pos, Name: stmt[0].lit}
+		kv.LineEnd = stmt[len(stmt)-1].pos
+		valueTokens := stmt[1:]
+		if len(valueTokens) > 0 && valueTokens[0].tok == token.ASSIGN {
+			valueTokens = valueTokens[1:]
+		}
+		kv.Value = p.sliceTokens(valueTokens)
+		out = append(out, kv)
+	}
+	return out
+}
+
+// parseModelBody reads field and block-attribute statements until the
+// closing brace of a model block.
+func (p *Parser) parseModelBody() ([]*ast.FieldDecl, []*ast.AttributeExpr) {
+	var fields []*ast.FieldDecl
+	var attrs []*ast.AttributeExpr
+	for p.cur().tok != token.RBRACE && !p.atEOF() {
+		doc, _ := p.collectDocComments()
+		if p.cur().tok == token.RBRACE || p.atEOF() {
+			// A comment on its own line with nothing left to attach
+			// to before the closing brace; dropped.
+			break
+		}
+		if p.cur().tok == token.AT2 {
+			stmt, _ := p.parseStatementTokens()
+			if a := p.parseAttributeFromTokens(stmt); a != nil {
+				attrs = append(attrs, a)
+			}
+			continue
+		}
+		stmt, trailingComment := p.parseStatementTokens()
+		if len(stmt) == 0 {
+			continue
+		}
+		fields = append(fields, p.parseFieldFromTokens(doc, stmt, trailingComment))
+	}
+	return fields, attrs
+}
+
+// parseEnumBody reads value statements until the closing brace of an
+// enum block.
+func (p *Parser) parseEnumBody() []*ast.EnumValueDecl {
+	var values []*ast.EnumValueDecl
+	for p.cur().tok != token.RBRACE && !p.atEOF() {
+		doc, _ := p.collectDocComments()
+		if p.cur().tok == token.RBRACE || p.atEOF() {
+			break
+		}
+		stmt, _ := p.parseStatementTokens()
+		if len(stmt) == 0 {
+			continue
+		}
+		ev := &ast.EnumValueDecl{Doc: doc, NamePos: stmt[0].pos, Name: stmt[0].lit}
+		ev.LineEnd = stmt[len(stmt)-1].pos
+		ev.Attributes = p.attributesFromTokens(stmt[1:])
+		values = append(values, ev)
+	}
+	return values
+}
+
+func (p *Parser) parseFieldFromTokens(doc *ast.CommentGroup, stmt []item, trailingComment *item) *ast.FieldDecl {
+	f := &ast.FieldDecl{Doc: doc, NamePos: stmt[0].pos, Name: stmt[0].lit}
+	f.LineEnd = stmt[len(stmt)-1].pos
+	i := 1
+	if i < len(stmt) && stmt[i].tok == token.IDENT {
+		f.Type = stmt[i].lit
+		i++
+	}
+	if i < len(stmt) && stmt[i].tok == token.LBRACK {
+		f.List = true
+		i++
+		if i < len(stmt) && stmt[i].tok == token.RBRACK {
+			i++
+		}
+	}
+	if i < len(stmt) && stmt[i].tok == token.QMARK {
+		f.Optional = true
+		i++
+	}
+	f.Attributes = p.attributesFromTokens(stmt[i:])
+	if trailingComment != nil {
+		f.Comment = &ast.Comment{Slash: trailingComment.pos, Text: trailingComment.lit, IsDoc: trailingComment.tok == token.DOC_COMMENT}
+		f.LineEnd = trailingComment.pos
+	}
+	return f
+}
+
+// attributesFromTokens parses a run of "@name(args)" / "@@name(args)"
+// attribute expressions from the tail of a statement. Argument text is
+// sliced verbatim out of the original source rather than re-joined from
+// token literals, so punctuation inside arguments (nested calls,
+// "fields: [id]", etc.) round-trips exactly instead of gaining or losing
+// whitespace.
+func (p *Parser) attributesFromTokens(items []item) []*ast.AttributeExpr {
+	var out []*ast.AttributeExpr
+	for i := 0; i < len(items); {
+		if items[i].tok != token.AT && items[i].tok != token.AT2 {
+			i++
+			continue
+		}
+		block := items[i].tok == token.AT2
+		atPos := items[i].pos
+		i++
+		if i >= len(items) || items[i].tok != token.IDENT {
+			break
+		}
+		name := items[i].lit
+		i++
+		// A native-type attribute namespaces its name with a dot, e.g.
+		// @db.VarChar; fold the "." IDENT that follows into name instead
+		// of treating it as a separate, unrecognized attribute.
+		for i+1 < len(items) && items[i].tok == token.PERIOD && items[i+1].tok == token.IDENT {
+			name += "." + items[i+1].lit
+			i += 2
+		}
+		var args []string
+		hasParens := false
+		if i < len(items) && items[i].tok == token.LPAREN {
+			hasParens = true
+			i++
+			depth := 1
+			argStart := i
+			for i < len(items) && depth > 0 {
+				switch items[i].tok {
+				case token.LPAREN:
+					depth++
+				case token.RPAREN:
+					depth--
+					if depth == 0 {
+						if i > argStart {
+							args = append(args, p.sliceTokens(items[argStart:i]))
+						}
+						i++
+						continue
+					}
+				case token.COMMA:
+					if depth == 1 {
+						args = append(args, p.sliceTokens(items[argStart:i]))
+						i++
+						argStart = i
+						continue
+					}
+				}
+				i++
+			}
+		}
+		out = append(out, &ast.AttributeExpr{AtPos: atPos, Name: name, Block: block, Args: args, HasParens: hasParens})
+	}
+	return out
+}
+
+func (p *Parser) parseAttributeFromTokens(stmt []item) *ast.AttributeExpr {
+	attrs := p.attributesFromTokens(stmt)
+	if len(attrs) == 0 {
+		return nil
+	}
+	return attrs[0]
+}
+
+// tokenByteLen returns the length in bytes of a token's source text. For
+// tokens that carry a literal (identifiers, strings, numbers, comments)
+// this is just len(lit); fixed punctuation tokens don't bother storing
+// their (known) text, so it's hardcoded here.
+func tokenByteLen(it item) int {
+	if it.lit != "" {
+		return len(it.lit)
+	}
+	if it.tok == token.AT2 {
+		return 2
+	}
+	return 1
+}
+
+// sliceTokens returns the verbatim source text spanned by items, trimmed
+// of surrounding whitespace. Used anywhere we want to preserve exactly
+// what the author wrote (attribute arguments, assignment values) instead
+// of re-deriving it from token literals.
+func (p *Parser) sliceTokens(items []item) string {
+	if len(items) == 0 {
+		return ""
+	}
+	start := items[0].pos.Offset
+	last := items[len(items)-1]
+	end := last.pos.Offset + tokenByteLen(last)
+	if start < 0 || end > len(p.src) || start > end {
+		return ""
+	}
+	return strings.TrimSpace(string(p.src[start:end]))
+}
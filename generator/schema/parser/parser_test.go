@@ -0,0 +1,229 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/steebchen/prisma-client-go/generator/schema/ast"
+)
+
+func TestParseAssignments(t *testing.T) {
+	tests := []struct {
+		name  string
+		src   string
+		key   string
+		value string
+	}{
+		{
+			name:  "simple string value",
+			src:   "generator client {\n  provider = \"prisma-client-go\"\n}\n",
+			key:   "provider",
+			value: `"prisma-client-go"`,
+		},
+		{
+			name:  "function call value",
+			src:   "datasource db {\n  url = env(\"DATABASE_URL\")\n}\n",
+			key:   "url",
+			value: `env("DATABASE_URL")`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file, err := ParseString("", tt.src)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			var assignments []*ast.KeyValueDecl
+			switch d := file.Decls[0].(type) {
+			case *ast.GeneratorDecl:
+				assignments = d.Assignments
+			case *ast.DatasourceDecl:
+				assignments = d.Assignments
+			default:
+				t.Fatalf("unexpected decl type %T", d)
+			}
+			if len(assignments) != 1 {
+				t.Fatalf("got %d assignments, want 1", len(assignments))
+			}
+			kv := assignments[0]
+			if kv.Name != tt.key {
+				t.Errorf("Name = %q, want %q", kv.Name, tt.key)
+			}
+			// The regression this guards against: the value used to
+			// come out as "= <value>" because the ASSIGN token was
+			// sliced into it.
+			if kv.Value != tt.value {
+				t.Errorf("Value = %q, want %q", kv.Value, tt.value)
+			}
+		})
+	}
+}
+
+func TestParseFieldTrailingComment(t *testing.T) {
+	src := "model User {\n  id Int @id // primary key\n  name String\n}\n"
+	file, err := ParseString("", src)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	model, ok := file.Decls[0].(*ast.ModelDecl)
+	if !ok {
+		t.Fatalf("unexpected decl type %T", file.Decls[0])
+	}
+	if len(model.Fields) != 2 {
+		t.Fatalf("got %d fields, want 2", len(model.Fields))
+	}
+	id := model.Fields[0]
+	if id.Comment == nil || id.Comment.Text != "// primary key" {
+		t.Errorf("Fields[0].Comment = %#v, want text %q", id.Comment, "// primary key")
+	}
+	if len(id.Attributes) != 1 || id.Attributes[0].Name != "id" {
+		t.Errorf("Fields[0].Attributes = %#v, want a single @id", id.Attributes)
+	}
+	if model.Fields[1].Comment != nil {
+		t.Errorf("Fields[1].Comment = %#v, want nil", model.Fields[1].Comment)
+	}
+}
+
+func TestParseStringLiteralBraces(t *testing.T) {
+	// Braces inside string literals and attribute arguments must not be
+	// mistaken for block delimiters by the token-based parser.
+	src := "model User {\n  bio String @default(\"{not a block}\")\n}\n"
+	file, err := ParseString("", src)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	model, ok := file.Decls[0].(*ast.ModelDecl)
+	if !ok {
+		t.Fatalf("unexpected decl type %T", file.Decls[0])
+	}
+	if len(model.Fields) != 1 {
+		t.Fatalf("got %d fields, want 1", len(model.Fields))
+	}
+	f := model.Fields[0]
+	if len(f.Attributes) != 1 || f.Attributes[0].Name != "default" {
+		t.Fatalf("Attributes = %#v, want a single @default", f.Attributes)
+	}
+	if got, want := f.Attributes[0].Args[0], `"{not a block}"`; got != want {
+		t.Errorf("Args[0] = %q, want %q", got, want)
+	}
+}
+
+func TestParseUnknownBlockPreservedVerbatim(t *testing.T) {
+	src := "view UserInfo {\n  id   String\n  name String\n}\n"
+	file, err := ParseString("", src)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if len(file.Decls) != 1 {
+		t.Fatalf("got %d decls, want 1", len(file.Decls))
+	}
+	other, ok := file.Decls[0].(*ast.OtherDecl)
+	if !ok {
+		t.Fatalf("unexpected decl type %T", file.Decls[0])
+	}
+	if !other.IsBlock {
+		t.Errorf("IsBlock = false, want true")
+	}
+	if other.Raw != src[:len(src)-1] { // without the trailing newline
+		t.Errorf("Raw = %q, want %q", other.Raw, src[:len(src)-1])
+	}
+}
+
+func TestParseUnmatchedBraceRecordsError(t *testing.T) {
+	src := "model User {\n  id Int @id\n"
+	_, errs := ParseWithErrors("schema.prisma", []byte(src))
+	if len(errs) != 1 {
+		t.Fatalf("got %d errors, want 1: %v", len(errs), errs)
+	}
+	if errs[0].Pos.Line != 1 {
+		t.Errorf("error line = %d, want 1", errs[0].Pos.Line)
+	}
+}
+
+func TestParseMultipleErrorsCollected(t *testing.T) {
+	// Two illegal characters should both be reported, instead of
+	// ParseWithErrors stopping at the first one.
+	src := "model User {\n  a $ Int\n  b % Int\n}\n"
+	_, errs := ParseWithErrors("", []byte(src))
+	if len(errs) != 2 {
+		t.Fatalf("got %d errors, want 2: %v", len(errs), errs)
+	}
+}
+
+func TestParseEnumBody(t *testing.T) {
+	src := "enum Role {\n  USER\n  ADMIN @map(\"admin\")\n}\n"
+	file, err := ParseString("", src)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	enum, ok := file.Decls[0].(*ast.EnumDecl)
+	if !ok {
+		t.Fatalf("unexpected decl type %T", file.Decls[0])
+	}
+	if len(enum.Values) != 2 {
+		t.Fatalf("got %d values, want 2", len(enum.Values))
+	}
+	if enum.Values[0].Name != "USER" {
+		t.Errorf("Values[0].Name = %q, want USER", enum.Values[0].Name)
+	}
+	if len(enum.Values[1].Attributes) != 1 || enum.Values[1].Attributes[0].Name != "map" {
+		t.Errorf("Values[1].Attributes = %#v, want a single @map", enum.Values[1].Attributes)
+	}
+}
+
+func TestParseNativeTypeAttribute(t *testing.T) {
+	src := "model User {\n  name String @db.VarChar(255)\n}\n"
+	file, err := ParseString("", src)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	model, ok := file.Decls[0].(*ast.ModelDecl)
+	if !ok {
+		t.Fatalf("unexpected decl type %T", file.Decls[0])
+	}
+	f := model.Fields[0]
+	if len(f.Attributes) != 1 || f.Attributes[0].Name != "db.VarChar" {
+		t.Fatalf("Attributes = %#v, want a single @db.VarChar", f.Attributes)
+	}
+	if got, want := f.Attributes[0].Args[0], "255"; got != want {
+		t.Errorf("Args[0] = %q, want %q", got, want)
+	}
+}
+
+func TestParseNegativeDefault(t *testing.T) {
+	src := "model User {\n  rank Int @default(-1)\n}\n"
+	file, err := ParseString("", src)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	model, ok := file.Decls[0].(*ast.ModelDecl)
+	if !ok {
+		t.Fatalf("unexpected decl type %T", file.Decls[0])
+	}
+	f := model.Fields[0]
+	if len(f.Attributes) != 1 || f.Attributes[0].Name != "default" {
+		t.Fatalf("Attributes = %#v, want a single @default", f.Attributes)
+	}
+	if got, want := f.Attributes[0].Args[0], "-1"; got != want {
+		t.Errorf("Args[0] = %q, want %q", got, want)
+	}
+}
+
+func TestIsBlockKeyword(t *testing.T) {
+	if !IsBlockKeyword("model") {
+		t.Errorf("IsBlockKeyword(%q) = false, want true", "model")
+	}
+	if IsBlockKeyword("view") {
+		t.Errorf("IsBlockKeyword(%q) = true, want false", "view")
+	}
+}
+
+func TestAssignValueTokenSkipped(t *testing.T) {
+	file, err := ParseString("", "datasource db {\n  provider = \"postgresql\"\n}\n")
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	ds := file.Decls[0].(*ast.DatasourceDecl)
+	if got, want := ds.Assignments[0].Value, `"postgresql"`; got != want {
+		t.Fatalf("Value = %q, want %q (ASSIGN token must not be sliced into the value)", got, want)
+	}
+}
@@ -0,0 +1,243 @@
+// Package scanner implements a lexical scanner for Prisma schema source,
+// in the same spirit as go/scanner: it turns raw bytes into a stream of
+// tokens while keeping track of source positions, so that callers never
+// need to reason about braces or comments as raw characters again.
+package scanner
+
+import (
+	"fmt"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/steebchen/prisma-client-go/generator/schema/token"
+)
+
+// ErrorHandler is called for each lexical error encountered. If nil,
+// errors are only counted in Scanner.ErrorCount.
+type ErrorHandler func(pos token.Position, msg string)
+
+// Scanner tokenizes Prisma schema source. Use Init to prepare it and Scan
+// to pull tokens one at a time until it reports token.EOF.
+type Scanner struct {
+	filename string
+	src      []byte
+	err      ErrorHandler
+
+	ch         rune // current character
+	offset     int  // offset of ch
+	rdOffset   int  // reading offset (offset of next character)
+	line       int
+	lineOffset int // offset of the start of the current line
+
+	ErrorCount int
+}
+
+// Init prepares the scanner to tokenize src. filename is used only for
+// position reporting and may be empty.
+func (s *Scanner) Init(filename string, src []byte, err ErrorHandler) {
+	s.filename = filename
+	s.src = src
+	s.err = err
+
+	s.ch = ' '
+	s.offset = 0
+	s.rdOffset = 0
+	s.line = 1
+	s.lineOffset = 0
+	s.ErrorCount = 0
+
+	s.next()
+}
+
+// next advances to the next rune in the source.
+func (s *Scanner) next() {
+	if s.rdOffset >= len(s.src) {
+		s.offset = len(s.src)
+		s.ch = -1 // EOF
+		return
+	}
+
+	s.offset = s.rdOffset
+	if s.ch == '\n' {
+		s.line++
+		s.lineOffset = s.offset
+	}
+
+	r, w := rune(s.src[s.rdOffset]), 1
+	if r >= utf8.RuneSelf {
+		r, w = utf8.DecodeRune(s.src[s.rdOffset:])
+	}
+	s.rdOffset += w
+	s.ch = r
+}
+
+func (s *Scanner) peek() byte {
+	if s.rdOffset < len(s.src) {
+		return s.src[s.rdOffset]
+	}
+	return 0
+}
+
+func (s *Scanner) position(offset int) token.Position {
+	return token.Position{
+		Filename: s.filename,
+		Offset:   offset,
+		Line:     s.line,
+		Column:   offset - s.lineOffset + 1,
+	}
+}
+
+func (s *Scanner) error(offset int, msg string) {
+	s.ErrorCount++
+	if s.err != nil {
+		s.err(s.position(offset), msg)
+	}
+}
+
+func isLetter(ch rune) bool {
+	return ch == '_' || ch >= 'a' && ch <= 'z' || ch >= 'A' && ch <= 'Z' || unicode.IsLetter(ch)
+}
+
+func isDigit(ch rune) bool {
+	return ch >= '0' && ch <= '9'
+}
+
+func (s *Scanner) skipWhitespace() {
+	for s.ch == ' ' || s.ch == '\t' || s.ch == '\n' || s.ch == '\r' {
+		s.next()
+	}
+}
+
+// scanIdentifier consumes an identifier or keyword starting at the
+// current character.
+func (s *Scanner) scanIdentifier() string {
+	offset := s.offset
+	for isLetter(s.ch) || isDigit(s.ch) {
+		s.next()
+	}
+	return string(s.src[offset:s.offset])
+}
+
+// scanNumber consumes an integer or floating point literal, including an
+// optional leading "-" so negative attribute arguments such as
+// @default(-1) parse.
+func (s *Scanner) scanNumber() (token.Token, string) {
+	offset := s.offset
+	tok := token.INT
+	if s.ch == '-' {
+		s.next()
+	}
+	for isDigit(s.ch) {
+		s.next()
+	}
+	if s.ch == '.' && isDigit(rune(s.peek())) {
+		tok = token.FLOAT
+		s.next()
+		for isDigit(s.ch) {
+			s.next()
+		}
+	}
+	return tok, string(s.src[offset:s.offset])
+}
+
+// scanString consumes a double-quoted string literal, including escaped
+// characters, so that braces or quotes inside e.g. @default("}") never
+// confuse block boundary detection upstream.
+func (s *Scanner) scanString() string {
+	offset := s.offset
+	s.next() // consume opening quote
+	for s.ch != '"' {
+		if s.ch == '\n' || s.ch < 0 {
+			s.error(offset, "string literal not terminated")
+			break
+		}
+		if s.ch == '\\' {
+			s.next()
+		}
+		s.next()
+	}
+	s.next() // consume closing quote
+	return string(s.src[offset:s.offset])
+}
+
+// scanComment consumes a line comment starting at the current "//" and
+// returns its text (without trailing newline) along with whether it is a
+// "///" doc comment.
+func (s *Scanner) scanComment() (string, bool) {
+	offset := s.offset
+	s.next() // consume first '/'
+	s.next() // consume second '/'
+	isDoc := s.ch == '/'
+	for s.ch != '\n' && s.ch >= 0 {
+		s.next()
+	}
+	return string(s.src[offset:s.offset]), isDoc
+}
+
+// Scan returns the position, kind, and literal text of the next token.
+// It returns token.EOF once the input is exhausted.
+func (s *Scanner) Scan() (pos token.Position, tok token.Token, lit string) {
+	s.skipWhitespace()
+	pos = s.position(s.offset)
+
+	switch ch := s.ch; {
+	case ch < 0:
+		tok = token.EOF
+	case isLetter(ch):
+		lit = s.scanIdentifier()
+		tok = token.IDENT
+	case isDigit(ch), ch == '-' && isDigit(rune(s.peek())):
+		tok, lit = s.scanNumber()
+	case ch == '"':
+		lit = s.scanString()
+		tok = token.STRING
+	case ch == '/' && s.peek() == '/':
+		text, isDoc := s.scanComment()
+		lit = text
+		if isDoc {
+			tok = token.DOC_COMMENT
+		} else {
+			tok = token.COMMENT
+		}
+	case ch == '@':
+		s.next()
+		if s.ch == '@' {
+			s.next()
+			tok = token.AT2
+		} else {
+			tok = token.AT
+		}
+	default:
+		s.next()
+		switch ch {
+		case '{':
+			tok = token.LBRACE
+		case '}':
+			tok = token.RBRACE
+		case '(':
+			tok = token.LPAREN
+		case ')':
+			tok = token.RPAREN
+		case '[':
+			tok = token.LBRACK
+		case ']':
+			tok = token.RBRACK
+		case ',':
+			tok = token.COMMA
+		case '=':
+			tok = token.ASSIGN
+		case '?':
+			tok = token.QMARK
+		case ':':
+			tok = token.COLON
+		case '.':
+			tok = token.PERIOD
+		default:
+			tok = token.ILLEGAL
+			lit = string(ch)
+			s.error(pos.Offset, fmt.Sprintf("unexpected character %q", ch))
+		}
+	}
+
+	return pos, tok, lit
+}
@@ -0,0 +1,109 @@
+// Package token defines the lexical tokens of the Prisma schema language
+// and basic position tracking, mirroring the role go/token plays for Go
+// source.
+package token
+
+import "fmt"
+
+// Token is the set of lexical tokens of a Prisma schema.
+type Token int
+
+const (
+	// ILLEGAL marks a byte sequence the scanner could not classify.
+	ILLEGAL Token = iota
+	// EOF marks the end of the input.
+	EOF
+
+	// COMMENT is a "// ..." line comment.
+	COMMENT
+	// DOC_COMMENT is a "/// ..." triple-slash doc comment.
+	DOC_COMMENT
+
+	// IDENT is an identifier, e.g. a block, field, or attribute name.
+	IDENT
+	// STRING is a double-quoted string literal, e.g. "prisma-client-go".
+	STRING
+	// INT is an integer literal.
+	INT
+	// FLOAT is a floating point literal.
+	FLOAT
+
+	LBRACE // {
+	RBRACE // }
+	LPAREN // (
+	RPAREN // )
+	LBRACK // [
+	RBRACK // ]
+
+	AT     // @, a field-level attribute
+	AT2    // @@, a block-level attribute
+	COMMA  // ,
+	ASSIGN // =
+	QMARK  // ?, optional field modifier
+	COLON  // :, used in named attribute arguments such as fields: [id]
+	PERIOD // ., joins a native-type attribute's namespace and name, e.g. db.VarChar
+)
+
+var tokenNames = map[Token]string{
+	ILLEGAL:     "ILLEGAL",
+	EOF:         "EOF",
+	COMMENT:     "COMMENT",
+	DOC_COMMENT: "DOC_COMMENT",
+	IDENT:       "IDENT",
+	STRING:      "STRING",
+	INT:         "INT",
+	FLOAT:       "FLOAT",
+	LBRACE:      "{",
+	RBRACE:      "}",
+	LPAREN:      "(",
+	RPAREN:      ")",
+	LBRACK:      "[",
+	RBRACK:      "]",
+	AT:          "@",
+	AT2:         "@@",
+	COMMA:       ",",
+	ASSIGN:      "=",
+	QMARK:       "?",
+	COLON:       ":",
+	PERIOD:      ".",
+}
+
+// String returns the textual name of the token, for use in error messages.
+func (t Token) String() string {
+	if s, ok := tokenNames[t]; ok {
+		return s
+	}
+	return fmt.Sprintf("token(%d)", int(t))
+}
+
+// Pos is a byte offset into a single schema file's source text.
+type Pos int
+
+// NoPos means "no position", analogous to go/token.NoPos.
+const NoPos Pos = 0
+
+// Position describes a source location in a form suitable for error
+// messages and diagnostics.
+type Position struct {
+	Filename string // file path, empty for single-file parses
+	Offset   int    // byte offset, starting at 0
+	Line     int    // line number, starting at 1
+	Column   int    // column number in bytes, starting at 1
+}
+
+// IsValid reports whether the position is meaningful.
+func (p Position) IsValid() bool {
+	return p.Line > 0
+}
+
+// String formats the position the way Go tooling formats file:line:col.
+func (p Position) String() string {
+	s := p.Filename
+	if s == "" {
+		s = "<input>"
+	}
+	if p.IsValid() {
+		s += fmt.Sprintf(":%d:%d", p.Line, p.Column)
+	}
+	return s
+}
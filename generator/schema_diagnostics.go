@@ -0,0 +1,211 @@
+package generator
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/steebchen/prisma-client-go/generator/schema/ast"
+	"github.com/steebchen/prisma-client-go/generator/schema/parser"
+	"github.com/steebchen/prisma-client-go/generator/schema/scanner"
+	"github.com/steebchen/prisma-client-go/generator/schema/token"
+)
+
+// Severity classifies how serious a Diagnostic is.
+type Severity int
+
+const (
+	SeverityError Severity = iota
+	SeverityWarning
+)
+
+func (s Severity) String() string {
+	if s == SeverityWarning {
+		return "warning"
+	}
+	return "error"
+}
+
+// Diagnostic is a single problem found while analyzing a schema, in a
+// form suitable for editor squiggles or a JSON error list, as opposed to
+// FilterByGenerator's fail-on-first-problem fmt.Errorf.
+type Diagnostic struct {
+	Severity Severity
+	File     string
+	Line     int
+	Col      int
+	Message  string
+	Code     string
+}
+
+// Diagnose parses content and returns every problem found instead of
+// stopping at the first one, alongside the merged AST.
+func Diagnose(content string) ([]Diagnostic, *ast.File) {
+	return NewSchemaParser(content).Diagnose()
+}
+
+// Diagnose runs the same analysis as the package-level Diagnose but
+// across every file known to p, so it also catches problems that only
+// exist across files: a generator or datasource defined differently in
+// two files, or a model/enum declared in more than one.
+func (p *SchemaParser) Diagnose() ([]Diagnostic, *ast.File) {
+	var diags []Diagnostic
+	merged := &ast.File{}
+
+	generators := map[string]*ast.GeneratorDecl{}
+	datasources := map[string]*ast.DatasourceDecl{}
+	models := map[string]*ast.ModelDecl{}
+	enums := map[string]*ast.EnumDecl{}
+
+	for _, f := range p.files {
+		diags = append(diags, unknownBlockDiagnostics(f.Filename, f.Content)...)
+
+		file, errs := parser.ParseWithErrors(f.Filename, []byte(f.Content))
+		for _, e := range errs {
+			diags = append(diags, diagnosticFromParseError(e))
+		}
+
+		for _, decl := range file.Decls {
+			switch d := decl.(type) {
+			case *ast.GeneratorDecl:
+				if prev, ok := generators[d.Name]; ok && !sameAssignments(prev.Assignments, d.Assignments) {
+					diags = append(diags, conflictDiagnostic("generator", d.Name, prev.Pos(), d.Pos()))
+				}
+				generators[d.Name] = d
+			case *ast.DatasourceDecl:
+				if prev, ok := datasources[d.Name]; ok && !sameAssignments(prev.Assignments, d.Assignments) {
+					diags = append(diags, conflictDiagnostic("datasource", d.Name, prev.Pos(), d.Pos()))
+				}
+				datasources[d.Name] = d
+			case *ast.ModelDecl:
+				if prev, ok := models[d.Name]; ok {
+					diags = append(diags, conflictDiagnostic("model", d.Name, prev.Pos(), d.Pos()))
+				}
+				models[d.Name] = d
+			case *ast.EnumDecl:
+				if prev, ok := enums[d.Name]; ok {
+					diags = append(diags, conflictDiagnostic("enum", d.Name, prev.Pos(), d.Pos()))
+				}
+				enums[d.Name] = d
+			}
+			merged.Decls = append(merged.Decls, decl)
+		}
+	}
+
+	diags = append(diags, unreferencedEnumDiagnostics(merged, enums)...)
+
+	return diags, merged
+}
+
+func diagnosticFromParseError(e parser.Error) Diagnostic {
+	code := "parse-error"
+	switch {
+	case strings.Contains(e.Msg, "unmatched"):
+		code = "unmatched-braces"
+	case strings.Contains(e.Msg, "not terminated"):
+		code = "unterminated-string"
+	case strings.Contains(e.Msg, "unexpected character"):
+		code = "illegal-character"
+	}
+	return Diagnostic{
+		Severity: SeverityError,
+		File:     e.Pos.Filename,
+		Line:     e.Pos.Line,
+		Col:      e.Pos.Column,
+		Message:  e.Msg,
+		Code:     code,
+	}
+}
+
+func conflictDiagnostic(kind, name string, first, second token.Position) Diagnostic {
+	return Diagnostic{
+		Severity: SeverityError,
+		File:     second.Filename,
+		Line:     second.Line,
+		Col:      second.Column,
+		Message:  fmt.Sprintf("%s %q is already defined at %s", kind, name, first),
+		Code:     "duplicate-" + kind,
+	}
+}
+
+// unknownBlockDiagnostics scans content for "<ident> <ident> {" whose
+// keyword isn't one the parser recognizes (generator, datasource,
+// model, enum), e.g. a newer block kind such as "view". This is a
+// separate, purely read-only token scan rather than a parser error,
+// since an unrecognized keyword should still round-trip through
+// FilterByGenerator/FormatSchema unchanged instead of becoming a hard
+// failure; it's only surfaced here as a warning.
+func unknownBlockDiagnostics(filename, content string) []Diagnostic {
+	var sc scanner.Scanner
+	sc.Init(filename, []byte(content), nil)
+
+	var diags []Diagnostic
+	var window [2]struct {
+		tok token.Token
+		pos token.Position
+		lit string
+	}
+
+	for {
+		pos, tok, lit := sc.Scan()
+		if tok == token.EOF {
+			break
+		}
+		if tok == token.LBRACE &&
+			window[0].tok == token.IDENT && window[1].tok == token.IDENT &&
+			!parser.IsBlockKeyword(window[0].lit) {
+			diags = append(diags, Diagnostic{
+				Severity: SeverityWarning,
+				File:     window[0].pos.Filename,
+				Line:     window[0].pos.Line,
+				Col:      window[0].pos.Column,
+				Message:  fmt.Sprintf("unknown block kind %q", window[0].lit),
+				Code:     "unknown-block-kind",
+			})
+		}
+		window[0] = window[1]
+		window[1] = struct {
+			tok token.Token
+			pos token.Position
+			lit string
+		}{tok, pos, lit}
+	}
+	return diags
+}
+
+// unreferencedEnumDiagnostics warns about enums no model field uses.
+func unreferencedEnumDiagnostics(merged *ast.File, enums map[string]*ast.EnumDecl) []Diagnostic {
+	used := map[string]bool{}
+	for _, decl := range merged.Decls {
+		m, ok := decl.(*ast.ModelDecl)
+		if !ok {
+			continue
+		}
+		for _, f := range m.Fields {
+			used[f.Type] = true
+		}
+	}
+
+	names := make([]string, 0, len(enums))
+	for name := range enums {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var diags []Diagnostic
+	for _, name := range names {
+		if used[name] {
+			continue
+		}
+		e := enums[name]
+		diags = append(diags, Diagnostic{
+			Severity: SeverityWarning,
+			File:     e.Pos().Filename,
+			Line:     e.Pos().Line,
+			Col:      e.Pos().Column,
+			Message:  fmt.Sprintf("enum %q is never referenced by any model field", name),
+			Code:     "unreferenced-enum",
+		})
+	}
+	return diags
+}
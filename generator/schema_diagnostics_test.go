@@ -0,0 +1,163 @@
+package generator
+
+import "testing"
+
+func diagnosticWithCode(diags []Diagnostic, code string) *Diagnostic {
+	for i := range diags {
+		if diags[i].Code == code {
+			return &diags[i]
+		}
+	}
+	return nil
+}
+
+func TestDiagnoseUnmatchedBraces(t *testing.T) {
+	diags, _ := Diagnose(`model User {
+  id Int @id
+`)
+	d := diagnosticWithCode(diags, "unmatched-braces")
+	if d == nil {
+		t.Fatalf("no unmatched-braces diagnostic in %#v", diags)
+	}
+	if d.Severity != SeverityError {
+		t.Errorf("Severity = %v, want %v", d.Severity, SeverityError)
+	}
+}
+
+func TestDiagnoseUnknownBlockKind(t *testing.T) {
+	diags, _ := Diagnose(`view UserInfo {
+  id String
+}
+`)
+	d := diagnosticWithCode(diags, "unknown-block-kind")
+	if d == nil {
+		t.Fatalf("no unknown-block-kind diagnostic in %#v", diags)
+	}
+	if d.Severity != SeverityWarning {
+		t.Errorf("Severity = %v, want %v", d.Severity, SeverityWarning)
+	}
+}
+
+func TestDiagnoseDuplicateModel(t *testing.T) {
+	diags, _ := Diagnose(`model User {
+  id Int @id
+}
+model User {
+  id Int @id
+}
+`)
+	d := diagnosticWithCode(diags, "duplicate-model")
+	if d == nil {
+		t.Fatalf("no duplicate-model diagnostic in %#v", diags)
+	}
+}
+
+func TestDiagnoseDuplicateEnum(t *testing.T) {
+	diags, _ := Diagnose(`enum Role {
+  USER
+}
+enum Role {
+  ADMIN
+}
+`)
+	d := diagnosticWithCode(diags, "duplicate-enum")
+	if d == nil {
+		t.Fatalf("no duplicate-enum diagnostic in %#v", diags)
+	}
+}
+
+func TestDiagnoseGeneratorCollisionAcrossFiles(t *testing.T) {
+	p := NewSchemaParser(`generator client {
+  provider = "prisma-client-go"
+}
+`)
+	p.Merge(NewSchemaParser(`generator client {
+  provider = "go"
+}
+`))
+
+	diags, _ := p.Diagnose()
+	d := diagnosticWithCode(diags, "duplicate-generator")
+	if d == nil {
+		t.Fatalf("no duplicate-generator diagnostic in %#v", diags)
+	}
+}
+
+func TestDiagnoseNoConflictForIdenticalGenerator(t *testing.T) {
+	shared := `generator client {
+  provider = "prisma-client-go"
+}
+`
+	p := NewSchemaParser(shared)
+	p.Merge(NewSchemaParser(shared))
+
+	diags, _ := p.Diagnose()
+	if d := diagnosticWithCode(diags, "duplicate-generator"); d != nil {
+		t.Errorf("unexpected duplicate-generator diagnostic for an identical redefinition: %#v", d)
+	}
+}
+
+func TestDiagnoseUnreferencedEnum(t *testing.T) {
+	diags, _ := Diagnose(`enum Role {
+  USER
+  ADMIN
+}
+model User {
+  id Int @id
+}
+`)
+	d := diagnosticWithCode(diags, "unreferenced-enum")
+	if d == nil {
+		t.Fatalf("no unreferenced-enum diagnostic in %#v", diags)
+	}
+	if d.Severity != SeverityWarning {
+		t.Errorf("Severity = %v, want %v", d.Severity, SeverityWarning)
+	}
+}
+
+func TestDiagnoseReferencedEnumHasNoWarning(t *testing.T) {
+	diags, _ := Diagnose(`enum Role {
+  USER
+  ADMIN
+}
+model User {
+  id   Int  @id
+  role Role
+}
+`)
+	if d := diagnosticWithCode(diags, "unreferenced-enum"); d != nil {
+		t.Errorf("unexpected unreferenced-enum diagnostic for a used enum: %#v", d)
+	}
+}
+
+func TestDiagnoseCleanSchemaHasNoDiagnostics(t *testing.T) {
+	diags, _ := Diagnose(`generator client {
+  provider = "prisma-client-go"
+}
+datasource db {
+  provider = "postgresql"
+  url      = env("DATABASE_URL")
+}
+enum Role {
+  USER
+}
+model User {
+  id   Int  @id
+  role Role
+}
+`)
+	if len(diags) != 0 {
+		t.Errorf("got %d diagnostics for a clean schema, want 0: %#v", len(diags), diags)
+	}
+}
+
+func TestDiagnoseNativeTypeAndNegativeDefaultHasNoDiagnostics(t *testing.T) {
+	diags, _ := Diagnose(`model User {
+  rank Int    @default(-1)
+  name String @db.VarChar(255)
+}
+`)
+	if len(diags) != 0 {
+		t.Errorf("got %d diagnostics for a schema with a native type and negative default, want 0: %#v", len(diags), diags)
+	}
+}
@@ -2,204 +2,193 @@ package generator
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
 	"strings"
+
+	"github.com/steebchen/prisma-client-go/generator/schema/ast"
+	"github.com/steebchen/prisma-client-go/generator/schema/format"
+	"github.com/steebchen/prisma-client-go/generator/schema/parser"
 )
 
-// SchemaBlock represents a block in the Prisma schema
-type SchemaBlock struct {
-	Type     string // "generator", "datasource", "model", "enum"
-	Name     string
+// schemaFile is one named source that contributes blocks to a
+// SchemaParser, either the single string passed to NewSchemaParser (with
+// an empty name) or one *.prisma file discovered by
+// NewSchemaParserFromDir.
+type schemaFile struct {
+	Filename string
 	Content  string
-	StartPos int
-	EndPos   int
 }
 
-// SchemaParser handles parsing and filtering of Prisma schema content
+// SchemaParser handles parsing and filtering of Prisma schema content.
+// It supports both a single schema string and, via
+// NewSchemaParserFromDir and Merge, Prisma's multi-file schema layout,
+// where a directory of *.prisma files is treated as one logical schema.
 type SchemaParser struct {
-	content string
+	files []schemaFile
 }
 
 // NewSchemaParser creates a new schema parser with the given content
 func NewSchemaParser(content string) *SchemaParser {
 	return &SchemaParser{
-		content: content,
+		files: []schemaFile{{Content: content}},
 	}
 }
 
-// FilterByGenerator filters the schema to only include the specified generator
-// along with all datasources, models, enums, and other non-generator blocks
-func (p *SchemaParser) FilterByGenerator(generatorName string) (string, error) {
-	blocks, err := p.parseBlocks()
+// NewSchemaParserFromDir creates a schema parser from every *.prisma
+// file directly inside dir, as Prisma's multi-file schema support
+// expects. Files are read in name order so parsing is deterministic.
+func NewSchemaParserFromDir(dir string) (*SchemaParser, error) {
+	entries, err := os.ReadDir(dir)
 	if err != nil {
-		return "", fmt.Errorf("failed to parse schema blocks: %w", err)
+		return nil, fmt.Errorf("failed to read schema directory %s: %w", dir, err)
 	}
 
-	var filteredBlocks []SchemaBlock
-	var foundGenerator bool
-
-	for _, block := range blocks {
-		switch block.Type {
-		case "generator":
-			// Only include the specified generator
-			if block.Name == generatorName {
-				filteredBlocks = append(filteredBlocks, block)
-				foundGenerator = true
-			}
-		case "datasource", "model", "enum":
-			// Include all datasources, models, and enums
-			filteredBlocks = append(filteredBlocks, block)
-		default:
-			// Include any other non-generator blocks (comments, etc.)
-			filteredBlocks = append(filteredBlocks, block)
+	var names []string
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".prisma") {
+			continue
 		}
+		names = append(names, e.Name())
 	}
+	sort.Strings(names)
 
-	if !foundGenerator {
-		return "", fmt.Errorf("generator '%s' not found in schema", generatorName)
+	if len(names) == 0 {
+		return nil, fmt.Errorf("no .prisma files found in %s", dir)
 	}
 
-	return p.reconstructSchema(filteredBlocks), nil
+	p := &SchemaParser{}
+	for _, name := range names {
+		path := filepath.Join(dir, name)
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read schema file %s: %w", path, err)
+		}
+		p.files = append(p.files, schemaFile{Filename: path, Content: string(content)})
+	}
+	return p, nil
 }
 
-// parseBlocks parses the schema content into individual blocks
-func (p *SchemaParser) parseBlocks() ([]SchemaBlock, error) {
-	var blocks []SchemaBlock
-	lines := strings.Split(p.content, "\n")
-
-	i := 0
-	for i < len(lines) {
-		line := strings.TrimSpace(lines[i])
-
-		// Skip empty lines
-		if line == "" {
-			i++
-			continue
-		}
+// Merge folds the files of others into p, as if they had all been
+// passed to NewSchemaParserFromDir together. It does not itself parse or
+// validate the result; conflicts (duplicate generator/datasource
+// definitions that disagree, or any model/enum defined more than once)
+// are reported the next time p is parsed, e.g. by FilterByGenerator.
+func (p *SchemaParser) Merge(others ...*SchemaParser) {
+	for _, other := range others {
+		p.files = append(p.files, other.files...)
+	}
+}
 
-		// Handle comments as separate blocks
-		if strings.HasPrefix(line, "//") {
-			blocks = append(blocks, SchemaBlock{
-				Type:     "comment",
-				Content:  lines[i],
-				StartPos: i,
-				EndPos:   i,
-			})
-			i++
-			continue
+// parse parses every file in p and merges their blocks into a single
+// *ast.File, deduplicating generator/datasource blocks that are defined
+// identically in more than one file and erroring on ones that aren't, or
+// on any model/enum declared in more than one file.
+func (p *SchemaParser) parse() (*ast.File, error) {
+	merged := &ast.File{}
+	generators := map[string]*ast.GeneratorDecl{}
+	datasources := map[string]*ast.DatasourceDecl{}
+	models := map[string]ast.Decl{}
+	enums := map[string]ast.Decl{}
+
+	for _, f := range p.files {
+		file, err := parser.Parse(f.Filename, []byte(f.Content))
+		if err != nil {
+			return nil, err
 		}
 
-		// Check for block declarations
-		if block, endLine, found := p.parseBlockAt(lines, i); found {
-			blocks = append(blocks, block)
-			i = endLine + 1
-		} else {
-			// Handle standalone lines that aren't part of a block
-			blocks = append(blocks, SchemaBlock{
-				Type:     "other",
-				Content:  lines[i],
-				StartPos: i,
-				EndPos:   i,
-			})
-			i++
+		for _, decl := range file.Decls {
+			switch d := decl.(type) {
+			case *ast.GeneratorDecl:
+				if prev, ok := generators[d.Name]; ok {
+					if !sameAssignments(prev.Assignments, d.Assignments) {
+						return nil, fmt.Errorf("generator %q is defined differently in %s and %s", d.Name, prev.Pos(), d.Pos())
+					}
+					continue // identical redefinition, keep the first
+				}
+				generators[d.Name] = d
+			case *ast.DatasourceDecl:
+				if prev, ok := datasources[d.Name]; ok {
+					if !sameAssignments(prev.Assignments, d.Assignments) {
+						return nil, fmt.Errorf("datasource %q is defined differently in %s and %s", d.Name, prev.Pos(), d.Pos())
+					}
+					continue
+				}
+				datasources[d.Name] = d
+			case *ast.ModelDecl:
+				if prev, ok := models[d.Name]; ok {
+					return nil, fmt.Errorf("model %q is defined in both %s and %s", d.Name, prev.Pos(), d.Pos())
+				}
+				models[d.Name] = d
+			case *ast.EnumDecl:
+				if prev, ok := enums[d.Name]; ok {
+					return nil, fmt.Errorf("enum %q is defined in both %s and %s", d.Name, prev.Pos(), d.Pos())
+				}
+				enums[d.Name] = d
+			}
+			merged.Decls = append(merged.Decls, decl)
 		}
 	}
 
-	return blocks, nil
+	return merged, nil
 }
 
-// parseBlockAt attempts to parse a block starting at the given line index
-func (p *SchemaParser) parseBlockAt(lines []string, startLine int) (SchemaBlock, int, bool) {
-	line := strings.TrimSpace(lines[startLine])
-
-	// Check for block types
-	blockTypes := []string{"generator", "datasource", "model", "enum"}
-	var blockType, blockName string
-
-	for _, bt := range blockTypes {
-		if strings.HasPrefix(line, bt+" ") {
-			blockType = bt
-			// Extract block name
-			parts := strings.Fields(line)
-			if len(parts) >= 2 {
-				blockName = parts[1]
-			}
-			break
-		}
+// sameAssignments reports whether two generator/datasource bodies set
+// the same keys to the same values, regardless of order.
+func sameAssignments(a, b []*ast.KeyValueDecl) bool {
+	if len(a) != len(b) {
+		return false
 	}
-
-	if blockType == "" {
-		return SchemaBlock{}, 0, false
+	values := make(map[string]string, len(a))
+	for _, kv := range a {
+		values[kv.Name] = kv.Value
 	}
-
-	// Find the opening brace
-	openBracePos := strings.Index(line, "{")
-	if openBracePos == -1 {
-		// Look for opening brace on subsequent lines
-		for j := startLine + 1; j < len(lines); j++ {
-			if strings.Contains(lines[j], "{") {
-				openBracePos = 0 // Found on a different line
-				break
-			}
-		}
-		if openBracePos == -1 {
-			return SchemaBlock{}, 0, false
+	for _, kv := range b {
+		if v, ok := values[kv.Name]; !ok || v != kv.Value {
+			return false
 		}
 	}
+	return true
+}
 
-	// Find the matching closing brace
-	braceCount := 0
-	var contentLines []string
-	endLine := startLine
-
-	for i := startLine; i < len(lines); i++ {
-		currentLine := lines[i]
-		contentLines = append(contentLines, currentLine)
-
-		// Count braces in this line
-		for _, char := range currentLine {
-			if char == '{' {
-				braceCount++
-			} else if char == '}' {
-				braceCount--
-				if braceCount == 0 {
-					endLine = i
-					goto blockComplete
-				}
+// FilterByGenerator filters the schema to only include the specified generator
+// along with all datasources, models, enums, and other non-generator blocks.
+// The result is always canonically formatted, as if FormatSchema had been
+// run over it (see generator/schema/format).
+func (p *SchemaParser) FilterByGenerator(generatorName string) (string, error) {
+	file, err := p.parse()
+	if err != nil {
+		return "", fmt.Errorf("failed to parse schema: %w", err)
+	}
+
+	var foundGenerator bool
+	filtered := &ast.File{Filename: file.Filename}
+	for _, decl := range file.Decls {
+		if g, ok := decl.(*ast.GeneratorDecl); ok {
+			if g.Name != generatorName {
+				continue
 			}
+			foundGenerator = true
 		}
+		filtered.Decls = append(filtered.Decls, decl)
 	}
 
-blockComplete:
-	if braceCount != 0 {
-		return SchemaBlock{}, 0, false // Unmatched braces
+	if !foundGenerator {
+		return "", fmt.Errorf("generator '%s' not found in schema", generatorName)
 	}
 
-	return SchemaBlock{
-		Type:     blockType,
-		Name:     blockName,
-		Content:  strings.Join(contentLines, "\n"),
-		StartPos: startLine,
-		EndPos:   endLine,
-	}, endLine, true
+	return string(format.FormatFile(filtered)), nil
 }
 
-// reconstructSchema rebuilds the schema from filtered blocks
-func (p *SchemaParser) reconstructSchema(blocks []SchemaBlock) string {
-	var result []string
-
-	for i, block := range blocks {
-		// Add the block content
-		result = append(result, block.Content)
-
-		// Add spacing between blocks (except for the last block)
-		if i < len(blocks)-1 {
-			// Don't add extra spacing if the next block is a comment or empty
-			nextBlock := blocks[i+1]
-			if nextBlock.Type != "comment" && strings.TrimSpace(nextBlock.Content) != "" {
-				result = append(result, "")
-			}
-		}
+// FormatSchema parses and canonically formats Prisma schema content, the
+// schema-language counterpart to gofmt: consistent indentation, aligned
+// field columns, normalized attribute arg spacing, and at most one blank
+// line between blocks.
+func FormatSchema(content string) (string, error) {
+	formatted, err := format.Format([]byte(content))
+	if err != nil {
+		return "", fmt.Errorf("failed to format schema: %w", err)
 	}
-
-	return strings.Join(result, "\n")
+	return string(formatted), nil
 }
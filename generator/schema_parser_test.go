@@ -0,0 +1,177 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeSchemaFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+		t.Fatalf("WriteFile(%s) error = %v", name, err)
+	}
+}
+
+func TestFilterByGenerator(t *testing.T) {
+	src := `generator client {
+  provider = "prisma-client-go"
+}
+generator other {
+  provider = "other-generator"
+}
+datasource db {
+  provider = "postgresql"
+  url      = env("DATABASE_URL")
+}
+model User {
+  id Int @id
+}
+`
+	out, err := NewSchemaParser(src).FilterByGenerator("client")
+	if err != nil {
+		t.Fatalf("FilterByGenerator() error = %v", err)
+	}
+	if strings.Contains(out, "other-generator") {
+		t.Errorf("output still contains the filtered-out generator:\n%s", out)
+	}
+	if !strings.Contains(out, `generator client {`) || !strings.Contains(out, "model User {") {
+		t.Errorf("output is missing expected blocks:\n%s", out)
+	}
+}
+
+func TestFilterByGeneratorNotFound(t *testing.T) {
+	src := `generator client {
+  provider = "prisma-client-go"
+}
+`
+	if _, err := NewSchemaParser(src).FilterByGenerator("missing"); err == nil {
+		t.Fatal("FilterByGenerator() error = nil, want an error for a missing generator")
+	}
+}
+
+func TestMultiFileSchemaMerge(t *testing.T) {
+	dir := t.TempDir()
+	writeSchemaFile(t, dir, "a_datasource.prisma", `datasource db {
+  provider = "postgresql"
+  url      = env("DATABASE_URL")
+}
+generator client {
+  provider = "prisma-client-go"
+}
+`)
+	writeSchemaFile(t, dir, "b_models.prisma", `model User {
+  id    Int    @id
+  posts Post[]
+}
+model Post {
+  id     Int  @id
+  author User @relation(fields: [authorId], references: [id])
+}
+`)
+
+	p, err := NewSchemaParserFromDir(dir)
+	if err != nil {
+		t.Fatalf("NewSchemaParserFromDir() error = %v", err)
+	}
+
+	out, err := p.FilterByGenerator("client")
+	if err != nil {
+		t.Fatalf("FilterByGenerator() error = %v", err)
+	}
+	for _, want := range []string{"datasource db {", "generator client {", "model User {", "model Post {"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("merged output is missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestMergeIdenticalGeneratorIsDeduplicated(t *testing.T) {
+	shared := `generator client {
+  provider = "prisma-client-go"
+}
+`
+	a := NewSchemaParser(shared + "model User {\n  id Int @id\n}\n")
+	b := NewSchemaParser(shared + "model Post {\n  id Int @id\n}\n")
+	a.Merge(b)
+
+	out, err := a.FilterByGenerator("client")
+	if err != nil {
+		t.Fatalf("FilterByGenerator() error = %v", err)
+	}
+	if n := strings.Count(out, "generator client {"); n != 1 {
+		t.Errorf("generator block appears %d times, want 1:\n%s", n, out)
+	}
+}
+
+func TestMergeConflictingGeneratorErrors(t *testing.T) {
+	a := NewSchemaParser(`generator client {
+  provider = "prisma-client-go"
+}
+`)
+	b := NewSchemaParser(`generator client {
+  provider = "go"
+}
+`)
+	a.Merge(b)
+
+	if _, err := a.FilterByGenerator("client"); err == nil {
+		t.Fatal("FilterByGenerator() error = nil, want an error for conflicting generator definitions")
+	}
+}
+
+func TestMergeDuplicateModelErrors(t *testing.T) {
+	a := NewSchemaParser(`generator client {
+  provider = "prisma-client-go"
+}
+model User {
+  id Int @id
+}
+`)
+	b := NewSchemaParser(`model User {
+  id Int @id
+}
+`)
+	a.Merge(b)
+
+	if _, err := a.FilterByGenerator("client"); err == nil {
+		t.Fatal("FilterByGenerator() error = nil, want an error for a model defined in two files")
+	}
+}
+
+func TestFilterByGeneratorKeepsNativeTypeAndNegativeDefault(t *testing.T) {
+	src := `generator client {
+  provider = "prisma-client-go"
+}
+model User {
+  rank Int    @default(-1)
+  name String @db.VarChar(255)
+}
+`
+	out, err := NewSchemaParser(src).FilterByGenerator("client")
+	if err != nil {
+		t.Fatalf("FilterByGenerator() error = %v", err)
+	}
+	if !strings.Contains(out, "@default(-1)") {
+		t.Errorf("output is missing the negative default:\n%s", out)
+	}
+	if !strings.Contains(out, "@db.VarChar(255)") {
+		t.Errorf("output is missing the native-type attribute:\n%s", out)
+	}
+}
+
+func TestFormatSchema(t *testing.T) {
+	out, err := FormatSchema(`model User{
+id Int @id
+name String
+}
+`)
+	if err != nil {
+		t.Fatalf("FormatSchema() error = %v", err)
+	}
+	want := "model User {\n  id   Int    @id\n  name String\n}"
+	if out != want {
+		t.Errorf("FormatSchema() = %q, want %q", out, want)
+	}
+}